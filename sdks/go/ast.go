@@ -0,0 +1,452 @@
+package craite
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "os/exec"
+    "strconv"
+    "strings"
+)
+
+// parseSolidityAST shells out to solc in --standard-json mode and returns
+// the parsed AST for the given source, plus the resolved compiler version
+// so downstream agents know which pragma the analysis matched.
+func parseSolidityAST(code string) (map[string]interface{}, string, error) {
+    if _, err := exec.LookPath("solc"); err != nil {
+        return nil, "", fmt.Errorf("solc not found: %w", err)
+    }
+
+    input := map[string]interface{}{
+        "language": "Solidity",
+        "sources": map[string]interface{}{
+            "contract.sol": map[string]interface{}{"content": code},
+        },
+        "settings": map[string]interface{}{
+            "outputSelection": map[string]interface{}{
+                "*": map[string]interface{}{"": []string{"ast"}},
+            },
+        },
+    }
+
+    payload, err := json.Marshal(input)
+    if err != nil {
+        return nil, "", err
+    }
+
+    cmd := exec.Command("solc", "--standard-json")
+    cmd.Stdin = bytes.NewReader(payload)
+    out, _ := cmd.Output()
+
+    var result struct {
+        Sources map[string]struct {
+            AST map[string]interface{} `json:"ast"`
+        } `json:"sources"`
+    }
+
+    if err := json.Unmarshal(out, &result); err != nil {
+        return nil, "", fmt.Errorf("parsing solc output: %w", err)
+    }
+
+    source, ok := result.Sources["contract.sol"]
+    if !ok || source.AST == nil {
+        return nil, "", fmt.Errorf("solc produced no AST")
+    }
+
+    return source.AST, solcVersion(), nil
+}
+
+// solcVersion reports the installed solc version (e.g. "0.8.24+commit...")
+// or "" if solc isn't available.
+func solcVersion() string {
+    out, err := exec.Command("solc", "--version").Output()
+    if err != nil {
+        return ""
+    }
+
+    for _, line := range strings.Split(string(out), "\n") {
+        if strings.HasPrefix(line, "Version:") {
+            return strings.TrimSpace(strings.TrimPrefix(line, "Version:"))
+        }
+    }
+
+    return ""
+}
+
+// astWalk visits every object node in a solc AST (or any sub-tree of it),
+// depth-first. solc's compact-JSON AST is untyped from Go's perspective, so
+// nodes are plain map[string]interface{} rather than a generated struct.
+func astWalk(node interface{}, visit func(map[string]interface{})) {
+    switch n := node.(type) {
+    case map[string]interface{}:
+        visit(n)
+        for _, v := range n {
+            astWalk(v, visit)
+        }
+    case []interface{}:
+        for _, v := range n {
+            astWalk(v, visit)
+        }
+    }
+}
+
+// lineAt converts a solc "start:length:fileIndex" src attribute into a
+// 1-based line number within code.
+func lineAt(code, src string) int {
+    start, _, ok := strings.Cut(src, ":")
+    if !ok {
+        return 0
+    }
+
+    offset, err := strconv.Atoi(start)
+    if err != nil || offset < 0 || offset > len(code) {
+        return 0
+    }
+
+    return strings.Count(code[:offset], "\n") + 1
+}
+
+func astIssue(issueType, severity, message, code string, n map[string]interface{}) map[string]interface{} {
+    src, _ := n["src"].(string)
+    return map[string]interface{}{
+        "type":     issueType,
+        "severity": severity,
+        "message":  message,
+        "line":     lineAt(code, src),
+    }
+}
+
+// astSecurityIssues walks a parsed AST and reports reentrancy, tx.origin,
+// block.timestamp, and delegatecall findings at genuine expression nodes,
+// rather than matching them inside comments, NatSpec, or string literals.
+func astSecurityIssues(ast map[string]interface{}, code string) []map[string]interface{} {
+    issues := []map[string]interface{}{}
+
+    stateVars := map[string]bool{}
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] == "VariableDeclaration" && n["stateVariable"] == true {
+            if name, ok := n["name"].(string); ok {
+                stateVars[name] = true
+            }
+        }
+    })
+
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] != "MemberAccess" {
+            return
+        }
+
+        memberName, _ := n["memberName"].(string)
+        expr, _ := n["expression"].(map[string]interface{})
+        exprName, _ := expr["name"].(string)
+
+        switch {
+        case memberName == "origin" && exprName == "tx":
+            issues = append(issues, astIssue("access_control", "medium", "tx.origin used for authentication", code, n))
+        case memberName == "timestamp" && exprName == "block":
+            issues = append(issues, astIssue("timestamp_dependence", "low", "Block timestamp used, can be manipulated by miners", code, n))
+        case memberName == "delegatecall":
+            issues = append(issues, astIssue("delegatecall", "high", "Delegatecall usage detected, ensure target is trusted", code, n))
+        }
+    })
+
+    issues = append(issues, astReentrancyIssues(ast, code, stateVars)...)
+
+    return issues
+}
+
+// astReentrancyIssues flags functions whose body contains a low-level
+// external call (.call/.send/.transfer) followed by a write to a state
+// variable in a later statement of the same function body, with nested
+// blocks, if branches and loop bodies flattened in (see flattenStatements) —
+// the checks-effects-interactions violation that enables reentrancy.
+func astReentrancyIssues(ast map[string]interface{}, code string, stateVars map[string]bool) []map[string]interface{} {
+    issues := []map[string]interface{}{}
+
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] != "FunctionDefinition" {
+            return
+        }
+
+        body, ok := n["body"].(map[string]interface{})
+        if !ok {
+            return
+        }
+
+        seenExternalCall := false
+        for _, stmt := range flattenStatements(body) {
+            if !seenExternalCall && containsExternalCall(stmt) {
+                seenExternalCall = true
+                continue
+            }
+
+            if seenExternalCall && writesStateVariable(stmt, stateVars) {
+                issues = append(issues, astIssue("reentrancy", "high",
+                    "State write after an external call; use checks-effects-interactions or a reentrancy guard", code, stmt))
+                break
+            }
+        }
+    })
+
+    return issues
+}
+
+// flattenStatements expands a statement node into the ordered sequence of
+// leaf statements it executes, recursing into Block/UncheckedBlock,
+// IfStatement (both branches), and loop bodies. Without this, a guarded
+// withdraw like `if (ok) { addr.call{value: amount}(""); balances[x] -=
+// amount; }` is a single top-level If statement to the caller, so the call
+// and the write can never be told apart as "this one first, that one
+// after" — they're compared as one statement against itself. Flattening
+// puts the call and the write on the flat list as two separate entries, in
+// the order they run, which is what the call-then-write scan needs.
+func flattenStatements(node interface{}) []map[string]interface{} {
+    stmt, ok := node.(map[string]interface{})
+    if !ok {
+        return nil
+    }
+
+    switch stmt["nodeType"] {
+    case "Block", "UncheckedBlock":
+        var out []map[string]interface{}
+        stmts, _ := stmt["statements"].([]interface{})
+        for _, s := range stmts {
+            out = append(out, flattenStatements(s)...)
+        }
+        return out
+    case "IfStatement":
+        var out []map[string]interface{}
+        out = append(out, flattenStatements(stmt["trueBody"])...)
+        out = append(out, flattenStatements(stmt["falseBody"])...)
+        return out
+    case "ForStatement", "WhileStatement", "DoWhileStatement":
+        return flattenStatements(stmt["body"])
+    default:
+        return []map[string]interface{}{stmt}
+    }
+}
+
+func containsExternalCall(node interface{}) bool {
+    found := false
+    astWalk(node, func(n map[string]interface{}) {
+        if n["nodeType"] != "MemberAccess" {
+            return
+        }
+        if member, _ := n["memberName"].(string); member == "call" || member == "send" || member == "transfer" {
+            found = true
+        }
+    })
+    return found
+}
+
+func writesStateVariable(node interface{}, stateVars map[string]bool) bool {
+    found := false
+    astWalk(node, func(n map[string]interface{}) {
+        if n["nodeType"] != "Assignment" {
+            return
+        }
+        lhs, _ := n["leftHandSide"].(map[string]interface{})
+        if stateVars[rootIdentifierName(lhs)] {
+            found = true
+        }
+    })
+    return found
+}
+
+// rootIdentifierName unwraps IndexAccess (mapping/array subscripts) and
+// MemberAccess (struct field access) nodes down to the root Identifier,
+// so that a write like `balances[msg.sender] -= amount` or
+// `position.collateral = 0` is recognized as a write to the state variable
+// it indexes into, not missed because the leftHandSide itself has no name.
+func rootIdentifierName(node map[string]interface{}) string {
+    for node != nil {
+        switch node["nodeType"] {
+        case "IndexAccess":
+            base, _ := node["baseExpression"].(map[string]interface{})
+            node = base
+        case "MemberAccess":
+            expr, _ := node["expression"].(map[string]interface{})
+            node = expr
+        default:
+            name, _ := node["name"].(string)
+            return name
+        }
+    }
+    return ""
+}
+
+// astCcipIssues walks the AST scoped to a _ccipReceive function body (rather
+// than grepping the whole file) to flag a missing onlyRouter modifier, an
+// unbounded loop over the inbound message's data, and no check of
+// message.sourceChainSelector against an allowlist. Scoping to the function
+// node means a comment or an unrelated onlyRouter-named modifier on some
+// other function can no longer silence these findings.
+func astCcipIssues(ast map[string]interface{}, code string) []map[string]interface{} {
+    issues := []map[string]interface{}{}
+
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] != "FunctionDefinition" || n["name"] != "_ccipReceive" {
+            return
+        }
+
+        if !hasModifier(n, "onlyRouter") {
+            issues = append(issues, astIssue("ccip_missing_only_router", "high",
+                "_ccipReceive is missing the onlyRouter modifier; anyone could spoof a CCIP message", code, n))
+        }
+
+        messageParam := firstParameterName(n)
+
+        body, ok := n["body"].(map[string]interface{})
+        if !ok {
+            return
+        }
+
+        if messageParam != "" && containsUnboundedMessageLoop(body, messageParam) {
+            issues = append(issues, astIssue("ccip_unbounded_loop", "medium",
+                "Looping over message data without a bound risks running out of gas and stranding the message", code, n))
+        }
+
+        if messageParam == "" || !checksSourceChainSelector(body, messageParam) {
+            issues = append(issues, astIssue("ccip_missing_source_check", "high",
+                "No check of message.sourceChainSelector against an allowlist; any source chain can call in", code, n))
+        }
+    })
+
+    return issues
+}
+
+// hasModifier reports whether a FunctionDefinition node carries a modifier
+// invocation with the given name.
+func hasModifier(fn map[string]interface{}, name string) bool {
+    mods, _ := fn["modifiers"].([]interface{})
+    for _, raw := range mods {
+        mod, ok := raw.(map[string]interface{})
+        if !ok {
+            continue
+        }
+        modifierName, _ := mod["modifierName"].(map[string]interface{})
+        if modifierName["name"] == name {
+            return true
+        }
+    }
+    return false
+}
+
+// firstParameterName returns the name of a FunctionDefinition's first
+// parameter (the inbound Client.Any2EVMMessage for _ccipReceive), or "" if
+// it has none.
+func firstParameterName(fn map[string]interface{}) string {
+    params, _ := fn["parameters"].(map[string]interface{})
+    list, _ := params["parameters"].([]interface{})
+    if len(list) == 0 {
+        return ""
+    }
+    first, _ := list[0].(map[string]interface{})
+    name, _ := first["name"].(string)
+    return name
+}
+
+// containsUnboundedMessageLoop reports whether body has a for/while loop
+// whose subtree references messageParam.data, the CCIP payload field most
+// often looped over without a length bound.
+func containsUnboundedMessageLoop(body interface{}, messageParam string) bool {
+    found := false
+    astWalk(body, func(n map[string]interface{}) {
+        if n["nodeType"] != "ForStatement" && n["nodeType"] != "WhileStatement" {
+            return
+        }
+        if referencesMember(n, messageParam, "data") {
+            found = true
+        }
+    })
+    return found
+}
+
+// checksSourceChainSelector reports whether body genuinely reads
+// messageParam.sourceChainSelector, as opposed to the string appearing only
+// in a comment or an unrelated part of the file.
+func checksSourceChainSelector(body interface{}, messageParam string) bool {
+    return referencesMember(body, messageParam, "sourceChainSelector")
+}
+
+// referencesMember reports whether node's subtree contains a MemberAccess
+// of the form base.member where base resolves to the given root identifier.
+func referencesMember(node interface{}, base, member string) bool {
+    found := false
+    astWalk(node, func(n map[string]interface{}) {
+        if n["nodeType"] != "MemberAccess" {
+            return
+        }
+        if memberName, _ := n["memberName"].(string); memberName != member {
+            return
+        }
+        expr, _ := n["expression"].(map[string]interface{})
+        if rootIdentifierName(expr) == base {
+            found = true
+        }
+    })
+    return found
+}
+
+// astGasSuggestions walks the AST for cached-length-in-loops and
+// public-vs-external visibility suggestions, the two checks the old
+// strings.Contains scan got wrong most often (a ForStatement condition
+// re-reading array.length every iteration looks identical to a cached one
+// in raw source unless you look at the parsed condition expression).
+func astGasSuggestions(ast map[string]interface{}, code string) []map[string]interface{} {
+    suggestions := []map[string]interface{}{}
+
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] != "ForStatement" {
+            return
+        }
+
+        condition, ok := n["condition"].(map[string]interface{})
+        if !ok {
+            return
+        }
+
+        lengthInCondition := false
+        astWalk(condition, func(c map[string]interface{}) {
+            if c["nodeType"] == "MemberAccess" {
+                if member, _ := c["memberName"].(string); member == "length" {
+                    lengthInCondition = true
+                }
+            }
+        })
+
+        if lengthInCondition {
+            src, _ := n["src"].(string)
+            suggestions = append(suggestions, map[string]interface{}{
+                "type":       "loops",
+                "suggestion": "Cache array length outside the loop",
+                "impact":     "medium",
+                "gas_saved":  "~100 per iteration",
+                "line":       lineAt(code, src),
+            })
+        }
+    })
+
+    astWalk(ast, func(n map[string]interface{}) {
+        if n["nodeType"] != "FunctionDefinition" {
+            return
+        }
+
+        visibility, _ := n["visibility"].(string)
+        kind, _ := n["kind"].(string)
+
+        if visibility == "public" && kind == "function" && n["overrides"] == nil {
+            src, _ := n["src"].(string)
+            name, _ := n["name"].(string)
+            suggestions = append(suggestions, map[string]interface{}{
+                "type":       "functions",
+                "suggestion": fmt.Sprintf("Function %q is public; use external if it's never called internally", name),
+                "impact":     "medium",
+                "gas_saved":  "~200 per call",
+                "line":       lineAt(code, src),
+            })
+        }
+    })
+
+    return suggestions
+}
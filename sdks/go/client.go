@@ -1,12 +1,16 @@
 package craite
 
 import (
+    "bufio"
     "bytes"
     "context"
     "encoding/json"
     "fmt"
     "io"
+    "math/rand"
     "net/http"
+    "strconv"
+    "strings"
     "time"
 )
 
@@ -16,6 +20,32 @@ type ApiClient struct {
     config     Config
 }
 
+// ProviderConfig is a single entry in Config.Providers: a provider to try,
+// with its own credentials, model, and optional endpoint override.
+type ProviderConfig struct {
+    Provider Provider
+    APIKey   string
+    Model    string
+    Endpoint string
+}
+
+// retryPolicy controls how many times, and with what backoff, ApiClient
+// retries a single provider before falling through to the next one.
+type retryPolicy struct {
+    base        time.Duration
+    cap         time.Duration
+    maxAttempts int
+}
+
+var defaultRetryPolicy = retryPolicy{base: 500 * time.Millisecond, cap: 30 * time.Second, maxAttempts: 5}
+
+// ProviderFallbackLogger, if set, is called whenever Generate or
+// GenerateStream gives up on one provider (retries exhausted, or a
+// non-retryable 4xx) and falls through to the next. The CLI wires this
+// up to its color printers so users can see which provider actually served
+// the response.
+var ProviderFallbackLogger func(from, to Provider, err error)
+
 // NewApiClient creates a new API client
 func NewApiClient(config Config) *ApiClient {
     return &ApiClient{
@@ -26,42 +56,186 @@ func NewApiClient(config Config) *ApiClient {
     }
 }
 
-// Generate makes an API call to generate code
-func (a *ApiClient) Generate(ctx context.Context, opts GenerateOptions) (*GenerateResult, error) {
-    systemPrompt := a.buildSystemPrompt(opts.Mode)
-    payload := a.buildPayload(opts, systemPrompt)
-    
-    req, err := http.NewRequestWithContext(ctx, "POST", a.getEndpoint(), bytes.NewBuffer(payload))
-    if err != nil {
-        return nil, fmt.Errorf("creating request: %w", err)
+// providers returns the ordered list of providers to try, falling back to
+// the single legacy Provider/APIKey/Model/Endpoint fields when Providers
+// wasn't set so existing callers keep working unchanged.
+func (a *ApiClient) providers() []ProviderConfig {
+    if len(a.config.Providers) > 0 {
+        return a.config.Providers
     }
-    
-    a.setHeaders(req)
-    
-    resp, err := a.httpClient.Do(req)
-    if err != nil {
-        return nil, fmt.Errorf("sending request: %w", err)
+
+    return []ProviderConfig{{
+        Provider: a.config.Provider,
+        APIKey:   a.config.APIKey,
+        Model:    a.config.Model,
+        Endpoint: a.config.Endpoint,
+    }}
+}
+
+// Generate makes an API call to generate code, trying each configured
+// provider in order. Within a provider it retries 429s (honoring
+// Retry-After) and 5xx responses with exponential backoff and full jitter
+// before falling through to the next provider with the same prompt.
+func (a *ApiClient) Generate(ctx context.Context, opts GenerateOptions) (*GenerateResult, error) {
+    providers := a.providers()
+
+    var lastErr error
+    for i, pc := range providers {
+        result, err := a.generateWithProvider(ctx, pc, opts)
+        if err == nil {
+            result.ProviderUsed = pc.Provider
+            return result, nil
+        }
+
+        if ctx.Err() != nil {
+            return nil, ctx.Err()
+        }
+
+        lastErr = err
+
+        if i+1 < len(providers) && ProviderFallbackLogger != nil {
+            ProviderFallbackLogger(pc.Provider, providers[i+1].Provider, err)
+        }
     }
-    defer resp.Body.Close()
-    
-    if resp.StatusCode != http.StatusOK {
+
+    return nil, fmt.Errorf("all providers exhausted: %w", lastErr)
+}
+
+// generateWithProvider runs the retry loop for a single provider.
+func (a *ApiClient) generateWithProvider(ctx context.Context, pc ProviderConfig, opts GenerateOptions) (*GenerateResult, error) {
+    client := &ApiClient{httpClient: a.httpClient, config: a.configFor(pc)}
+    systemPrompt := client.buildSystemPrompt(opts.Mode, opts.Language)
+    payload := client.buildPayload(opts, systemPrompt)
+
+    var lastErr error
+    for attempt := 0; attempt < defaultRetryPolicy.maxAttempts; attempt++ {
+        req, err := http.NewRequestWithContext(ctx, "POST", client.getEndpoint(), bytes.NewBuffer(payload))
+        if err != nil {
+            return nil, fmt.Errorf("creating request: %w", err)
+        }
+
+        client.setHeaders(req)
+
+        resp, err := client.httpClient.Do(req)
+        if err != nil {
+            if ctx.Err() != nil {
+                return nil, ctx.Err()
+            }
+            lastErr = fmt.Errorf("sending request: %w", err)
+            if !sleep(ctx, backoffDelay(attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        if resp.StatusCode == http.StatusOK {
+            defer resp.Body.Close()
+
+            var response map[string]interface{}
+            if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+                return nil, fmt.Errorf("decoding response: %w", err)
+            }
+
+            return client.parseResponse(response, opts.Language)
+        }
+
         body, _ := io.ReadAll(resp.Body)
-        return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+        resp.Body.Close()
+        lastErr = fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+
+        if resp.StatusCode == http.StatusTooManyRequests {
+            if !sleep(ctx, retryAfterDelay(resp.Header, attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        if resp.StatusCode >= 500 {
+            if !sleep(ctx, backoffDelay(attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        // Non-retryable 4xx: stop retrying this provider immediately.
+        return nil, lastErr
     }
-    
-    var response map[string]interface{}
-    if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-        return nil, fmt.Errorf("decoding response: %w", err)
+
+    return nil, lastErr
+}
+
+// configFor builds the Config a single-provider request should use,
+// inheriting everything except the provider-specific fields from the
+// top-level config (e.g. MCPTools).
+func (a *ApiClient) configFor(pc ProviderConfig) Config {
+    cfg := a.config
+    cfg.Provider = pc.Provider
+    cfg.APIKey = pc.APIKey
+    cfg.Model = pc.Model
+    cfg.Endpoint = pc.Endpoint
+    return cfg
+}
+
+// backoffDelay computes an exponential backoff with full jitter:
+// min(cap, base * 2^attempt) scaled by a random factor in [0, 1).
+func backoffDelay(attempt int) time.Duration {
+    cap := defaultRetryPolicy.cap
+    delay := defaultRetryPolicy.base * time.Duration(1<<uint(attempt))
+    if delay > cap || delay <= 0 {
+        delay = cap
+    }
+
+    return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// retryAfterDelay honors a 429's Retry-After header (seconds or HTTP-date),
+// falling back to exponential backoff when the header is absent or
+// unparseable.
+func retryAfterDelay(header http.Header, attempt int) time.Duration {
+    retryAfter := header.Get("Retry-After")
+    if retryAfter == "" {
+        retryAfter = header.Get("retry-after")
+    }
+
+    if retryAfter != "" {
+        if secs, err := strconv.Atoi(retryAfter); err == nil {
+            return time.Duration(secs) * time.Second
+        }
+        if when, err := http.ParseTime(retryAfter); err == nil {
+            if d := time.Until(when); d > 0 {
+                return d
+            }
+        }
+    }
+
+    return backoffDelay(attempt)
+}
+
+// sleep waits out delay, or returns false early if ctx is canceled first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+    timer := time.NewTimer(delay)
+    defer timer.Stop()
+
+    select {
+    case <-timer.C:
+        return true
+    case <-ctx.Done():
+        return false
     }
-    
-    return a.parseResponse(response, opts.Language)
 }
 
-func (a *ApiClient) buildSystemPrompt(mode Mode) string {
-    base := `You are CRAITE, an elite Web3 code generator and AI development assistant. 
-You specialize in blockchain development, smart contracts, dApps, DeFi protocols, and NFTs. 
+func (a *ApiClient) buildSystemPrompt(mode Mode, language string) string {
+    base := `You are CRAITE, an elite Web3 code generator and AI development assistant.
+You specialize in blockchain development, smart contracts, dApps, DeFi protocols, and NFTs.
 Generate production-ready, secure, and optimized code following best practices.`
-    
+
+    if language == "cosmwasm" {
+        base += `
+You are generating CosmWasm smart contracts in Rust for Cosmos SDK chains. Use cosmwasm-std
+and cw-storage-plus idioms, implement the instantiate/execute/query/migrate entrypoints with
+their standard signatures, and return results via ContractResult/Response rather than panicking.`
+    }
+
     switch mode {
     case Educational:
         return base + "\nProvide detailed explanations and comments to help the user learn."
@@ -162,11 +336,323 @@ func (a *ApiClient) parseResponse(response map[string]interface{}, language stri
         }
     }
     
-    code, explanation := extractCodeFromContent(content)
-    
+    code, explanation := ExtractCodeFromContent(content)
+
     return &GenerateResult{
         Code:        code,
         Language:    language,
         Explanation: explanation,
     }, nil
+}
+
+// GenerateChunk is a single piece of a streamed generation
+type GenerateChunk struct {
+    DeltaText string
+    Done      bool
+    Err       error
+}
+
+// GenerateStream opens a streaming generation request and returns a channel
+// of incrementally decoded chunks. Like Generate, it tries each configured
+// provider in turn with the same retry policy, falling through to the next
+// provider if a stream can't be opened; once a stream is open, decode errors
+// are reported on the channel rather than retried, since chunks may already
+// have been delivered to the caller.
+func (a *ApiClient) GenerateStream(ctx context.Context, opts GenerateOptions) (<-chan GenerateChunk, error) {
+    providers := a.providers()
+
+    var lastErr error
+    for i, pc := range providers {
+        client := &ApiClient{httpClient: a.httpClient, config: a.configFor(pc)}
+
+        body, err := client.openStream(ctx, opts)
+        if err == nil {
+            return client.decodeStream(ctx, body, pc.Provider), nil
+        }
+
+        if ctx.Err() != nil {
+            return nil, ctx.Err()
+        }
+
+        lastErr = err
+
+        if i+1 < len(providers) && ProviderFallbackLogger != nil {
+            ProviderFallbackLogger(pc.Provider, providers[i+1].Provider, err)
+        }
+    }
+
+    return nil, fmt.Errorf("all providers exhausted: %w", lastErr)
+}
+
+// openStream opens a single provider's streaming endpoint, retrying 429s
+// (honoring Retry-After) and 5xx responses with the same backoff as
+// generateWithProvider, and returns the response body once a 200 comes back.
+func (a *ApiClient) openStream(ctx context.Context, opts GenerateOptions) (io.ReadCloser, error) {
+    systemPrompt := a.buildSystemPrompt(opts.Mode, opts.Language)
+    payload := a.buildStreamPayload(opts, systemPrompt)
+
+    var lastErr error
+    for attempt := 0; attempt < defaultRetryPolicy.maxAttempts; attempt++ {
+        req, err := http.NewRequestWithContext(ctx, "POST", a.getEndpoint(), bytes.NewBuffer(payload))
+        if err != nil {
+            return nil, fmt.Errorf("creating request: %w", err)
+        }
+
+        a.setHeaders(req)
+
+        resp, err := a.httpClient.Do(req)
+        if err != nil {
+            if ctx.Err() != nil {
+                return nil, ctx.Err()
+            }
+            lastErr = fmt.Errorf("sending request: %w", err)
+            if !sleep(ctx, backoffDelay(attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        if resp.StatusCode == http.StatusOK {
+            return resp.Body, nil
+        }
+
+        body, _ := io.ReadAll(resp.Body)
+        resp.Body.Close()
+        lastErr = fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+
+        if resp.StatusCode == http.StatusTooManyRequests {
+            if !sleep(ctx, retryAfterDelay(resp.Header, attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        if resp.StatusCode >= 500 {
+            if !sleep(ctx, backoffDelay(attempt)) {
+                return nil, ctx.Err()
+            }
+            continue
+        }
+
+        // Non-retryable 4xx: stop retrying this provider immediately.
+        return nil, lastErr
+    }
+
+    return nil, lastErr
+}
+
+// decodeStream launches the per-provider frame decoder over an already-open
+// stream body and returns the channel of chunks it feeds.
+func (a *ApiClient) decodeStream(ctx context.Context, body io.ReadCloser, provider Provider) <-chan GenerateChunk {
+    chunks := make(chan GenerateChunk)
+
+    go func() {
+        defer body.Close()
+        defer close(chunks)
+
+        var decodeErr error
+        switch provider {
+        case OpenAI:
+            decodeErr = decodeOpenAIStream(ctx, body, chunks)
+        case Anthropic:
+            decodeErr = decodeAnthropicStream(ctx, body, chunks)
+        default:
+            decodeErr = decodeOllamaStream(ctx, body, chunks)
+        }
+
+        if decodeErr != nil {
+            select {
+            case chunks <- GenerateChunk{Err: decodeErr, Done: true}:
+            case <-ctx.Done():
+            }
+        }
+    }()
+
+    return chunks
+}
+
+// buildStreamPayload is identical to buildPayload but forces stream: true so
+// each provider opens its streaming endpoint instead of buffering the reply.
+func (a *ApiClient) buildStreamPayload(opts GenerateOptions, systemPrompt string) []byte {
+    var payload interface{}
+
+    switch a.config.Provider {
+    case OpenAI:
+        payload = map[string]interface{}{
+            "model": a.config.Model,
+            "messages": []map[string]string{
+                {"role": "system", "content": systemPrompt},
+                {"role": "user", "content": opts.Prompt},
+            },
+            "temperature": opts.Temperature,
+            "max_tokens":  opts.MaxTokens,
+            "stream":      true,
+        }
+    case Anthropic:
+        payload = map[string]interface{}{
+            "model":       a.config.Model,
+            "system":      systemPrompt,
+            "messages":    []map[string]string{{"role": "user", "content": opts.Prompt}},
+            "max_tokens":  opts.MaxTokens,
+            "temperature": opts.Temperature,
+            "stream":      true,
+        }
+    default:
+        payload = map[string]interface{}{
+            "model":       a.config.Model,
+            "prompt":      fmt.Sprintf("%s\n\n%s", systemPrompt, opts.Prompt),
+            "temperature": opts.Temperature,
+            "max_tokens":  opts.MaxTokens,
+            "stream":      true,
+        }
+    }
+
+    data, _ := json.Marshal(payload)
+    return data
+}
+
+// decodeOpenAIStream parses OpenAI's `data: {...}\n\n` SSE frames, emitting
+// each choice's delta content until the `[DONE]` sentinel is received.
+func decodeOpenAIStream(ctx context.Context, body io.Reader, chunks chan<- GenerateChunk) error {
+    scanner := bufio.NewScanner(body)
+
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if !strings.HasPrefix(line, "data:") {
+            continue
+        }
+
+        data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+        if data == "[DONE]" {
+            return sendChunk(ctx, chunks, GenerateChunk{Done: true})
+        }
+
+        var frame struct {
+            Choices []struct {
+                Delta struct {
+                    Content string `json:"content"`
+                } `json:"delta"`
+            } `json:"choices"`
+        }
+
+        if err := json.Unmarshal([]byte(data), &frame); err != nil {
+            continue
+        }
+
+        if len(frame.Choices) > 0 && frame.Choices[0].Delta.Content != "" {
+            if err := sendChunk(ctx, chunks, GenerateChunk{DeltaText: frame.Choices[0].Delta.Content}); err != nil {
+                return err
+            }
+        }
+    }
+
+    return scanner.Err()
+}
+
+// decodeAnthropicStream parses Anthropic's event stream, forwarding
+// `content_block_delta` text and stopping at `message_stop`.
+func decodeAnthropicStream(ctx context.Context, body io.Reader, chunks chan<- GenerateChunk) error {
+    scanner := bufio.NewScanner(body)
+
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if !strings.HasPrefix(line, "data:") {
+            continue
+        }
+
+        data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+        var frame struct {
+            Type  string `json:"type"`
+            Delta struct {
+                Text string `json:"text"`
+            } `json:"delta"`
+        }
+
+        if err := json.Unmarshal([]byte(data), &frame); err != nil {
+            continue
+        }
+
+        switch frame.Type {
+        case "content_block_delta":
+            if frame.Delta.Text != "" {
+                if err := sendChunk(ctx, chunks, GenerateChunk{DeltaText: frame.Delta.Text}); err != nil {
+                    return err
+                }
+            }
+        case "message_stop":
+            return sendChunk(ctx, chunks, GenerateChunk{Done: true})
+        }
+    }
+
+    return scanner.Err()
+}
+
+// decodeOllamaStream parses Ollama's newline-delimited JSON objects from
+// /api/generate, each carrying a `response` delta until `done: true`.
+func decodeOllamaStream(ctx context.Context, body io.Reader, chunks chan<- GenerateChunk) error {
+    scanner := bufio.NewScanner(body)
+
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" {
+            continue
+        }
+
+        var frame struct {
+            Response string `json:"response"`
+            Done     bool   `json:"done"`
+        }
+
+        if err := json.Unmarshal([]byte(line), &frame); err != nil {
+            continue
+        }
+
+        if frame.Response != "" {
+            if err := sendChunk(ctx, chunks, GenerateChunk{DeltaText: frame.Response}); err != nil {
+                return err
+            }
+        }
+
+        if frame.Done {
+            return sendChunk(ctx, chunks, GenerateChunk{Done: true})
+        }
+    }
+
+    return scanner.Err()
+}
+
+func sendChunk(ctx context.Context, chunks chan<- GenerateChunk, chunk GenerateChunk) error {
+    select {
+    case chunks <- chunk:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// ExtractCodeFromContent pulls the first fenced code block out of a model
+// response, treating everything else as explanatory text.
+func ExtractCodeFromContent(content string) (code string, explanation string) {
+    const fence = "```"
+
+    start := strings.Index(content, fence)
+    if start == -1 {
+        return strings.TrimSpace(content), ""
+    }
+
+    rest := content[start+len(fence):]
+    if nl := strings.Index(rest, "\n"); nl != -1 {
+        rest = rest[nl+1:]
+    }
+
+    end := strings.Index(rest, fence)
+    if end == -1 {
+        return strings.TrimSpace(rest), ""
+    }
+
+    code = strings.TrimSpace(rest[:end])
+    explanation = strings.TrimSpace(content[:start] + rest[end+len(fence):])
+
+    return code, explanation
 }
\ No newline at end of file
@@ -2,25 +2,30 @@ package main
 
 import (
     "context"
-    "encoding/json"
+    "embed"
     "fmt"
+    "io/fs"
     "io/ioutil"
     "os"
     "path/filepath"
     "strings"
-    
-    "github.com/craite/craite-go"
+    "text/template"
+
+    "github.com/CRAITE-CODE/craite/sdks/go"
     "github.com/joho/godotenv"
     "github.com/spf13/cobra"
     "github.com/fatih/color"
 )
 
+//go:embed templates/*
+var projectTemplates embed.FS
+
 var (
     // Global flags
     apiKey   string
     provider string
     model    string
-    
+
     // Color printers
     greenBold  = color.New(color.FgGreen, color.Bold).SprintFunc()
     redBold    = color.New(color.FgRed, color.Bold).SprintFunc()
@@ -42,9 +47,13 @@ func main() {
     
     // Global flags
     rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", os.Getenv("OPENAI_API_KEY"), "API key for LLM provider")
-    rootCmd.PersistentFlags().StringVar(&provider, "provider", "openai", "LLM provider (openai, anthropic, local)")
+    rootCmd.PersistentFlags().StringVar(&provider, "provider", "openai", "LLM provider, or a comma-separated fallback list (openai, anthropic, local)")
     rootCmd.PersistentFlags().StringVar(&model, "model", "gpt-4", "Model to use")
-    
+
+    craite.ProviderFallbackLogger = func(from, to craite.Provider, err error) {
+        fmt.Printf("%s %s %s\n", yellowBold("⚠ falling back from"), from, dimmed(fmt.Sprintf("(%v) to %v", err, to)))
+    }
+
     // Add commands
     rootCmd.AddCommand(generateCmd())
     rootCmd.AddCommand(scaffoldCmd())
@@ -64,6 +73,7 @@ func generateCmd() *cobra.Command {
         mode     string
         output   string
         tools    []string
+        stream   bool
     )
     
     cmd := &cobra.Command{
@@ -71,19 +81,24 @@ func generateCmd() *cobra.Command {
         Short: "Generate code from a prompt",
         Args:  cobra.ExactArgs(1),
         Run: func(cmd *cobra.Command, args []string) {
-            if apiKey == "" {
-                fmt.Println(redBold("Error:"), "API key is required. Set OPENAI_API_KEY or use --api-key")
-                os.Exit(1)
+            providers := parseProviderList(provider, apiKey, model, cmd.Flags().Changed("api-key"), cmd.Flags().Changed("model"))
+
+            for _, p := range providers {
+                if p.APIKey == "" {
+                    fmt.Println(redBold("Error:"), fmt.Sprintf("no API key resolved for provider %v; set its provider-specific env var or use --api-key", p.Provider))
+                    os.Exit(1)
+                }
             }
-            
+
             prompt := args[0]
             fmt.Println(greenBold("Generating code..."))
-            
+
             config := craite.Config{
-                APIKey:   apiKey,
-                Provider: parseProvider(provider),
-                Model:    model,
-                MCPTools: tools,
+                APIKey:    providers[0].APIKey,
+                Provider:  providers[0].Provider,
+                Model:     providers[0].Model,
+                MCPTools:  tools,
+                Providers: providers,
             }
             
             client := craite.NewClient(config)
@@ -97,24 +112,28 @@ func generateCmd() *cobra.Command {
             }
             
             ctx := context.Background()
-            
+
             var result *craite.GenerateResult
             var err error
-            
-            if len(tools) > 0 {
+
+            if stream {
+                result, err = streamGenerate(ctx, client, opts)
+            } else if len(tools) > 0 {
                 result, err = client.GenerateWithTools(ctx, opts, tools)
             } else {
                 result, err = client.Generate(ctx, opts)
             }
-            
+
             if err != nil {
                 fmt.Println(redBold("Error:"), err)
                 os.Exit(1)
             }
-            
-            fmt.Println("\n" + greenBold("Generated Code:"))
-            fmt.Println(strings.Repeat("─", 50))
-            fmt.Println(result.Code)
+
+            if !stream {
+                fmt.Println("\n" + greenBold("Generated Code:"))
+                fmt.Println(strings.Repeat("─", 50))
+                fmt.Println(result.Code)
+            }
             
             if result.Explanation != "" && mode == "educational" {
                 fmt.Println("\n" + cyanBold("Explanation:"))
@@ -139,10 +158,52 @@ func generateCmd() *cobra.Command {
     cmd.Flags().StringVarP(&mode, "mode", "m", "production", "Generation mode (production/educational)")
     cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path")
     cmd.Flags().StringSliceVarP(&tools, "tools", "t", []string{}, "MCP tools to use")
-    
+    cmd.Flags().BoolVarP(&stream, "stream", "s", false, "Stream the response as it's generated")
+
     return cmd
 }
 
+// streamingClient is satisfied by anything that can open a streaming
+// generation, so streamGenerate doesn't care whether it's handed the plain
+// ApiClient or the tool-aware wrapper around it.
+type streamingClient interface {
+    GenerateStream(ctx context.Context, opts craite.GenerateOptions) (<-chan craite.GenerateChunk, error)
+}
+
+// streamGenerate opens a streaming generation, printing deltas as they
+// arrive and buffering the full text so the caller can still extract code
+// and write --output once the stream completes.
+func streamGenerate(ctx context.Context, client streamingClient, opts craite.GenerateOptions) (*craite.GenerateResult, error) {
+    chunks, err := client.GenerateStream(ctx, opts)
+    if err != nil {
+        return nil, err
+    }
+
+    fmt.Println("\n" + greenBold("Generated Code:"))
+    fmt.Println(strings.Repeat("─", 50))
+
+    var buf strings.Builder
+    for chunk := range chunks {
+        if chunk.Err != nil {
+            return nil, chunk.Err
+        }
+        fmt.Print(chunk.DeltaText)
+        buf.WriteString(chunk.DeltaText)
+        if chunk.Done {
+            break
+        }
+    }
+    fmt.Println()
+
+    code, explanation := craite.ExtractCodeFromContent(buf.String())
+
+    return &craite.GenerateResult{
+        Code:        code,
+        Language:    opts.Language,
+        Explanation: explanation,
+    }, nil
+}
+
 func scaffoldCmd() *cobra.Command {
     var (
         name     string
@@ -154,22 +215,39 @@ func scaffoldCmd() *cobra.Command {
         Use:   "scaffold [contract-type]",
         Short: "Generate a smart contract scaffold",
         Args:  cobra.ExactArgs(1),
-        ValidArgs: []string{"ERC20", "ERC721", "ERC1155"},
+        ValidArgs: []string{"ERC20", "ERC721", "ERC1155", "cw20", "cw721", "ics20-ibc"},
         Run: func(cmd *cobra.Command, args []string) {
             contractType := args[0]
-            
+
+            if isCosmWasmScaffold(contractType) {
+                dir := output
+                if dir == "" {
+                    dir = name
+                }
+
+                fmt.Printf("%s %s CosmWasm crate...\n", greenBold("Scaffolding"), contractType)
+
+                if err := writeCosmWasmCrate(dir, contractType, name); err != nil {
+                    fmt.Println(redBold("Error scaffolding crate:"), err)
+                    os.Exit(1)
+                }
+
+                fmt.Printf("%s %s\n", greenBold("✓ Crate created at:"), dir)
+                return
+            }
+
             prompt := fmt.Sprintf("Create a %s contract named %s", contractType, name)
             if len(features) > 0 {
                 prompt += fmt.Sprintf(" with features: %s", strings.Join(features, ", "))
             }
-            
+
             // Reuse generate logic
             fmt.Printf("%s %s contract...\n", greenBold("Scaffolding"), contractType)
-            
+
             // Would implement similar to generate command
         },
     }
-    
+
     cmd.Flags().StringVarP(&name, "name", "n", "MyContract", "Contract name")
     cmd.Flags().StringSliceVarP(&features, "features", "f", []string{}, "Contract features")
     cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path")
@@ -181,6 +259,8 @@ func analyzeCmd() *cobra.Command {
     var (
         security bool
         gas      bool
+        tool     string
+        chain    string
     )
     
     cmd := &cobra.Command{
@@ -198,30 +278,30 @@ func analyzeCmd() *cobra.Command {
             
             fmt.Printf("%s %s\n", cyanBold("Analyzing:"), file)
             fmt.Println(strings.Repeat("─", 50))
-            
+
             registry := craite.NewMCPToolRegistry()
-            
+            language := detectLanguage(file)
+
             if security {
                 fmt.Println("\n" + redBold("Security Analysis:"))
-                
+
                 result := registry.Execute("security_audit", map[string]interface{}{
                     "code":     string(code),
-                    "language": "solidity",
+                    "language": language,
+                    "tool":     tool,
                 })
                 
                 if result.Success {
                     data := result.Data.(map[string]interface{})
                     fmt.Printf("Score: %v/100\n", data["score"])
                     
-                    if issues, ok := data["issues"].([]interface{}); ok && len(issues) > 0 {
+                    if issues, ok := data["issues"].([]map[string]interface{}); ok && len(issues) > 0 {
                         fmt.Println("\nIssues found:")
-                        for _, issue := range issues {
-                            if issueMap, ok := issue.(map[string]interface{}); ok {
-                                fmt.Printf("  • %s (%s): %s\n",
-                                    issueMap["type"],
-                                    issueMap["severity"],
-                                    issueMap["message"])
-                            }
+                        for _, issueMap := range issues {
+                            fmt.Printf("  • %s (%s): %s\n",
+                                issueMap["type"],
+                                issueMap["severity"],
+                                issueMap["message"])
                         }
                     } else {
                         fmt.Println(greenBold("✓ No security issues found!"))
@@ -233,7 +313,8 @@ func analyzeCmd() *cobra.Command {
                 fmt.Println("\n" + yellowBold("Gas Optimization:"))
                 
                 result := registry.Execute("gas_optimization", map[string]interface{}{
-                    "code": string(code),
+                    "code":  string(code),
+                    "chain": chain,
                 })
                 
                 if result.Success {
@@ -241,15 +322,13 @@ func analyzeCmd() *cobra.Command {
                     fmt.Printf("Optimization Score: %v/100\n", data["optimization_score"])
                     fmt.Printf("Estimated Savings: %v\n", data["estimated_total_savings"])
                     
-                    if suggestions, ok := data["suggestions"].([]interface{}); ok && len(suggestions) > 0 {
+                    if suggestions, ok := data["suggestions"].([]map[string]interface{}); ok && len(suggestions) > 0 {
                         fmt.Println("\nSuggestions:")
-                        for _, suggestion := range suggestions {
-                            if suggMap, ok := suggestion.(map[string]interface{}); ok {
-                                fmt.Printf("  • %s: %s (Impact: %s)\n",
-                                    suggMap["type"],
-                                    suggMap["suggestion"],
-                                    suggMap["impact"])
-                            }
+                        for _, suggMap := range suggestions {
+                            fmt.Printf("  • %s: %s (Impact: %s)\n",
+                                suggMap["type"],
+                                suggMap["suggestion"],
+                                suggMap["impact"])
                         }
                     } else {
                         fmt.Println(greenBold("✓ Code is well optimized!"))
@@ -261,10 +340,263 @@ func analyzeCmd() *cobra.Command {
     
     cmd.Flags().BoolVar(&security, "security", true, "Run security audit")
     cmd.Flags().BoolVar(&gas, "gas", true, "Run gas optimization")
-    
+    cmd.Flags().StringVar(&tool, "tool", "both", "Security scanner to use (slither, mythril, both)")
+    cmd.Flags().StringVar(&chain, "chain", "ethereum", "Target chain for gas heuristics (ethereum, celo, optimism, arbitrum, zksync)")
+
     return cmd
 }
 
+// detectLanguage picks the analyzer language from a file's extension,
+// defaulting to solidity for anything unrecognized.
+func detectLanguage(file string) string {
+    switch filepath.Ext(file) {
+    case ".rs":
+        return "cosmwasm"
+    default:
+        return "solidity"
+    }
+}
+
+// isCosmWasmScaffold reports whether the scaffold subtype is one of the
+// CosmWasm crate templates rather than a Solidity contract type.
+func isCosmWasmScaffold(contractType string) bool {
+    switch contractType {
+    case "cw20", "cw721", "ics20-ibc":
+        return true
+    default:
+        return false
+    }
+}
+
+// writeCosmWasmCrate lays down a full CosmWasm crate (Cargo.toml, src/lib.rs,
+// src/contract.rs, src/msg.rs, src/state.rs, schema/) for the given subtype.
+func writeCosmWasmCrate(dir, contractType, name string) error {
+    dirs := []string{
+        dir,
+        filepath.Join(dir, "src"),
+        filepath.Join(dir, "schema"),
+    }
+
+    for _, d := range dirs {
+        if err := os.MkdirAll(d, 0755); err != nil {
+            return err
+        }
+    }
+
+    files := map[string]string{
+        "Cargo.toml":      cosmWasmCargoToml(name),
+        "src/lib.rs":      cosmWasmLibRs(),
+        "src/msg.rs":      cosmWasmMsgRs(contractType),
+        "src/state.rs":    cosmWasmStateRs(contractType),
+        "src/contract.rs": cosmWasmContractRs(contractType),
+    }
+
+    for rel, contents := range files {
+        if err := os.WriteFile(filepath.Join(dir, rel), []byte(contents), 0644); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+func cosmWasmCargoToml(name string) string {
+    return fmt.Sprintf(`[package]
+name = "%s"
+version = "0.1.0"
+edition = "2021"
+
+[lib]
+crate-type = ["cdylib", "rlib"]
+
+[dependencies]
+cosmwasm-std = "1.5"
+cosmwasm-schema = "1.5"
+cw-storage-plus = "1.2"
+cw2 = "1.1"
+schemars = "0.8"
+serde = { version = "1.0", features = ["derive"] }
+thiserror = "1.0"
+`, name)
+}
+
+func cosmWasmLibRs() string {
+    return `pub mod contract;
+pub mod msg;
+pub mod state;
+
+pub use crate::contract::{execute, instantiate, migrate, query};
+`
+}
+
+func cosmWasmMsgRs(contractType string) string {
+    switch contractType {
+    case "ics20-ibc":
+        return `use cosmwasm_schema::{cw_serde, QueryResponses};
+
+#[cw_serde]
+pub struct InstantiateMsg {}
+
+#[cw_serde]
+pub enum ExecuteMsg {}
+
+#[cw_serde]
+#[derive(QueryResponses)]
+pub enum QueryMsg {}
+
+#[cw_serde]
+pub struct MigrateMsg {}
+`
+    default:
+        return `use cosmwasm_schema::{cw_serde, QueryResponses};
+
+#[cw_serde]
+pub struct InstantiateMsg {
+    pub name: String,
+    pub symbol: String,
+}
+
+#[cw_serde]
+pub enum ExecuteMsg {
+    Transfer { recipient: String, amount: u128 },
+}
+
+#[cw_serde]
+#[derive(QueryResponses)]
+pub enum QueryMsg {
+    #[returns(u128)]
+    Balance { address: String },
+}
+
+#[cw_serde]
+pub struct MigrateMsg {}
+`
+    }
+}
+
+func cosmWasmStateRs(contractType string) string {
+    if contractType == "ics20-ibc" {
+        return `use cw_storage_plus::Map;
+
+/// Replay-protection for inbound packets, keyed by (channel_id, sequence).
+pub const PROCESSED_PACKETS: Map<(&str, u64), bool> = Map::new("processed_packets");
+`
+    }
+
+    return `use cw_storage_plus::Map;
+
+pub const BALANCES: Map<&str, u128> = Map::new("balances");
+`
+}
+
+func cosmWasmContractRs(contractType string) string {
+    base := `use cosmwasm_std::{
+    entry_point, Binary, Deps, DepsMut, Env, MessageInfo, Response, StdResult,
+};
+
+use crate::msg::{ExecuteMsg, InstantiateMsg, MigrateMsg, QueryMsg};
+
+#[entry_point]
+pub fn instantiate(
+    _deps: DepsMut,
+    _env: Env,
+    _info: MessageInfo,
+    _msg: InstantiateMsg,
+) -> StdResult<Response> {
+    Ok(Response::new().add_attribute("method", "instantiate"))
+}
+
+#[entry_point]
+pub fn execute(
+    _deps: DepsMut,
+    _env: Env,
+    _info: MessageInfo,
+    _msg: ExecuteMsg,
+) -> StdResult<Response> {
+    Ok(Response::new())
+}
+
+#[entry_point]
+pub fn query(_deps: Deps, _env: Env, _msg: QueryMsg) -> StdResult<Binary> {
+    todo!("implement query handlers")
+}
+
+#[entry_point]
+pub fn migrate(_deps: DepsMut, _env: Env, _msg: MigrateMsg) -> StdResult<Response> {
+    Ok(Response::default())
+}
+`
+
+    if contractType != "ics20-ibc" {
+        return base
+    }
+
+    return base + `
+// IBC entrypoints for the ICS-20 style channel.
+
+#[entry_point]
+pub fn ibc_channel_open(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcChannelOpenMsg,
+) -> StdResult<cosmwasm_std::IbcChannelOpenResponse> {
+    // None accepts the channel's proposed version as-is; Some(Ibc3ChannelOpenResponse { version })
+    // would negotiate a different one.
+    Ok(None)
+}
+
+#[entry_point]
+pub fn ibc_channel_connect(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcChannelConnectMsg,
+) -> StdResult<cosmwasm_std::IbcBasicResponse> {
+    Ok(cosmwasm_std::IbcBasicResponse::default())
+}
+
+#[entry_point]
+pub fn ibc_channel_close(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcChannelCloseMsg,
+) -> StdResult<cosmwasm_std::IbcBasicResponse> {
+    Ok(cosmwasm_std::IbcBasicResponse::default())
+}
+
+#[entry_point]
+pub fn ibc_packet_receive(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcPacketReceiveMsg,
+) -> StdResult<cosmwasm_std::IbcReceiveResponse> {
+    // Check PROCESSED_PACKETS for (channel_id, sequence) before crediting,
+    // then record it, to guard against replay.
+    Ok(cosmwasm_std::IbcReceiveResponse::new(cosmwasm_std::IbcAcknowledgement::encode_json(
+        &cosmwasm_std::Empty {},
+    )?))
+}
+
+#[entry_point]
+pub fn ibc_packet_ack(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcPacketAckMsg,
+) -> StdResult<cosmwasm_std::IbcBasicResponse> {
+    Ok(cosmwasm_std::IbcBasicResponse::default())
+}
+
+#[entry_point]
+pub fn ibc_packet_timeout(
+    _deps: DepsMut,
+    _env: Env,
+    _msg: cosmwasm_std::IbcPacketTimeoutMsg,
+) -> StdResult<cosmwasm_std::IbcBasicResponse> {
+    // Refund/rollback the sender's escrowed balance on timeout.
+    Ok(cosmwasm_std::IbcBasicResponse::default())
+}
+`
+}
+
 func toolsCmd() *cobra.Command {
     return &cobra.Command{
         Use:   "tools",
@@ -301,48 +633,221 @@ func toolsCmd() *cobra.Command {
 }
 
 func initCmd() *cobra.Command {
-    var template string
-    
+    var (
+        template    string
+        license     string
+        solcVersion string
+        rustVersion string
+    )
+
     cmd := &cobra.Command{
         Use:   "init [project-name]",
         Short: "Create a new Web3 project",
         Args:  cobra.ExactArgs(1),
         Run: func(cmd *cobra.Command, args []string) {
             projectName := args[0]
-            
+
             fmt.Printf("%s %s project: %s\n", greenBold("Creating"), template, projectName)
-            
-            // Create project structure
-            dirs := []string{
-                filepath.Join(projectName, "contracts"),
-                filepath.Join(projectName, "scripts"),
-                filepath.Join(projectName, "test"),
-                filepath.Join(projectName, "docs"),
+
+            if template == "basic" {
+                initBasicProject(projectName)
+                return
             }
-            
-            for _, dir := range dirs {
-                if err := os.MkdirAll(dir, 0755); err != nil {
-                    fmt.Println(redBold("Error creating directory:"), err)
+
+            data := projectTemplateData{
+                ProjectName: projectName,
+                License:     license,
+                SolcVersion: solcVersion,
+                RustVersion: rustVersion,
+            }
+
+            if err := renderProjectTemplate(template, projectName, data); err != nil {
+                fmt.Println(redBold("Error creating project:"), err)
+                os.Exit(1)
+            }
+
+            if template == "cosmwasm" {
+                // The workspace Cargo.toml declares members = ["contracts/*"],
+                // so it needs at least one real crate underneath or `cargo
+                // build` has nothing to build; reuse the same scaffolding
+                // `scaffold cw20/cw721/ics20-ibc` writes rather than leaving
+                // the workspace empty.
+                contractDir := filepath.Join(projectName, "contracts", projectName)
+                if err := writeCosmWasmCrate(contractDir, "cw20", projectName); err != nil {
+                    fmt.Println(redBold("Error scaffolding starter crate:"), err)
                     os.Exit(1)
                 }
             }
-            
-            // Create basic files
-            // ... implementation
-            
+
             fmt.Println(greenBold("✓ Project created!"))
             fmt.Println("\n" + cyanBold("Next steps:"))
             fmt.Printf("  cd %s\n", projectName)
-            fmt.Println("  go mod init")
-            fmt.Println("  craite generate \"Create an ERC20 token\"")
+
+            switch template {
+            case "foundry":
+                fmt.Println("  forge build")
+            case "hardhat":
+                fmt.Println("  npm i && npx hardhat compile")
+            case "cosmwasm":
+                fmt.Println("  cargo build")
+            }
         },
     }
-    
-    cmd.Flags().StringVarP(&template, "template", "t", "basic", "Project template")
-    
+
+    cmd.Flags().StringVarP(&template, "template", "t", "basic", "Project template (basic, foundry, hardhat, cosmwasm)")
+    cmd.Flags().StringVar(&license, "license", "MIT", "License identifier for generated files")
+    cmd.Flags().StringVar(&solcVersion, "solc-version", "0.8.24", "Solidity compiler version (foundry/hardhat)")
+    cmd.Flags().StringVar(&rustVersion, "rust-version", "1.77.0", "Rust toolchain version (cosmwasm)")
+
     return cmd
 }
 
+// initBasicProject is the original template-free scaffold: empty
+// contracts/scripts/test/docs directories, left for users who don't want an
+// embedded build system wired up yet.
+func initBasicProject(projectName string) {
+    dirs := []string{
+        filepath.Join(projectName, "contracts"),
+        filepath.Join(projectName, "scripts"),
+        filepath.Join(projectName, "test"),
+        filepath.Join(projectName, "docs"),
+    }
+
+    for _, dir := range dirs {
+        if err := os.MkdirAll(dir, 0755); err != nil {
+            fmt.Println(redBold("Error creating directory:"), err)
+            os.Exit(1)
+        }
+    }
+
+    fmt.Println(greenBold("✓ Project created!"))
+    fmt.Println("\n" + cyanBold("Next steps:"))
+    fmt.Printf("  cd %s\n", projectName)
+    fmt.Println("  go mod init")
+    fmt.Println("  craite generate \"Create an ERC20 token\"")
+}
+
+// projectTemplateData is substituted into every file under
+// templates/<template>/ when rendering an init scaffold.
+type projectTemplateData struct {
+    ProjectName string
+    License     string
+    SolcVersion string
+    RustVersion string
+}
+
+// renderProjectTemplate walks the embedded templates/<name> directory,
+// executing every file as a text/template and writing the result under
+// destDir with the ".tmpl" suffix stripped.
+func renderProjectTemplate(name, destDir string, data projectTemplateData) error {
+    root := "templates/" + name
+
+    return fs.WalkDir(projectTemplates, root, func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.IsDir() {
+            return nil
+        }
+
+        rel := strings.TrimSuffix(strings.TrimPrefix(path, root+"/"), ".tmpl")
+        destPath := filepath.Join(destDir, rel)
+
+        if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+            return err
+        }
+
+        raw, err := projectTemplates.ReadFile(path)
+        if err != nil {
+            return err
+        }
+
+        tmpl, err := template.New(rel).Parse(string(raw))
+        if err != nil {
+            return fmt.Errorf("parsing template %s: %w", path, err)
+        }
+
+        out, err := os.Create(destPath)
+        if err != nil {
+            return err
+        }
+        defer out.Close()
+
+        return tmpl.Execute(out, data)
+    })
+}
+
+// parseProviderList turns the (possibly comma-separated) --provider value
+// into an ordered fallback list. The CLI only exposes one --api-key/--model
+// pair; unless set explicitly, that pair is only trusted for the provider it
+// actually matches (openai, where their defaults come from), and every other
+// provider resolves its own credentials and default model so a fallback to
+// e.g. anthropic doesn't retry with an OpenAI key and "gpt-4".
+func parseProviderList(p, apiKey, model string, apiKeyExplicit, modelExplicit bool) []craite.ProviderConfig {
+    names := strings.Split(p, ",")
+    providers := make([]craite.ProviderConfig, 0, len(names))
+
+    for _, name := range names {
+        name = strings.TrimSpace(name)
+        if name == "" {
+            continue
+        }
+        providers = append(providers, craite.ProviderConfig{
+            Provider: parseProvider(name),
+            APIKey:   providerAPIKey(name, apiKey, apiKeyExplicit),
+            Model:    providerModel(name, model, modelExplicit),
+        })
+    }
+
+    if len(providers) == 0 {
+        providers = append(providers, craite.ProviderConfig{
+            Provider: craite.OpenAI,
+            APIKey:   providerAPIKey("openai", apiKey, apiKeyExplicit),
+            Model:    providerModel("openai", model, modelExplicit),
+        })
+    }
+
+    return providers
+}
+
+// providerAPIKey resolves credentials for one entry in the fallback list.
+// An explicit --api-key always wins (the user asked for it); otherwise each
+// provider reads its own environment variable rather than reusing whatever
+// --api-key defaulted to (OPENAI_API_KEY), which is wrong for every
+// non-openai provider.
+func providerAPIKey(name, globalAPIKey string, explicit bool) string {
+    if explicit {
+        return globalAPIKey
+    }
+
+    switch name {
+    case "anthropic":
+        return os.Getenv("ANTHROPIC_API_KEY")
+    case "local":
+        return os.Getenv("LOCAL_API_KEY")
+    default:
+        return globalAPIKey
+    }
+}
+
+// providerModel resolves the model for one entry in the fallback list. An
+// explicit --model always wins; otherwise each non-openai provider falls
+// back to its own sane default instead of "gpt-4".
+func providerModel(name, globalModel string, explicit bool) string {
+    if explicit || name == "openai" {
+        return globalModel
+    }
+
+    switch name {
+    case "anthropic":
+        return "claude-3-5-sonnet-20241022"
+    case "local":
+        return "llama3"
+    default:
+        return globalModel
+    }
+}
+
 // Helper functions
 func parseProvider(p string) craite.Provider {
     switch p {
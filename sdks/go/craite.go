@@ -2,32 +2,133 @@
 package craite
 
 import (
-    "bytes"
+    "context"
     "encoding/json"
-    "net/http"
+    "fmt"
+    "strings"
 )
 
-// Client represents a CRAITE API client
+// Provider identifies which LLM backend a request targets.
+type Provider int
+
+const (
+    OpenAI Provider = iota
+    Anthropic
+    Local
+)
+
+// String renders the provider the way the CLI's fallback logger and error
+// messages display it.
+func (p Provider) String() string {
+    switch p {
+    case OpenAI:
+        return "openai"
+    case Anthropic:
+        return "anthropic"
+    case Local:
+        return "local"
+    default:
+        return "unknown"
+    }
+}
+
+// Mode controls how much explanation Generate asks the model to produce
+// alongside the code.
+type Mode int
+
+const (
+    Production Mode = iota
+    Educational
+)
+
+// Config configures a Client: its credentials, target model, and the
+// ordered list of providers ApiClient falls back across. See
+// ProviderConfig in client.go for a single entry in Providers.
+type Config struct {
+    APIKey    string
+    Provider  Provider
+    Model     string
+    Endpoint  string
+    MCPTools  []string
+    Providers []ProviderConfig
+}
+
+// GenerateOptions are the parameters for a single generation request.
+type GenerateOptions struct {
+    Prompt      string
+    Language    string
+    Mode        Mode
+    Temperature float64
+    MaxTokens   int
+}
+
+// GenerateResult is the outcome of a generation request.
+type GenerateResult struct {
+    Code         string
+    Language     string
+    Explanation  string
+    ToolsUsed    []string
+    ProviderUsed Provider
+}
+
+// Client is the public CRAITE SDK entry point. It wraps an ApiClient with
+// the default MCP tool registry so GenerateWithTools works without callers
+// wiring up the registry themselves.
 type Client struct {
-    APIKey   string
-    Endpoint string
+    api      *ApiClient
+    registry *MCPToolRegistry
 }
 
-// NewClient creates a new CRAITE client
-func NewClient(apiKey string) *Client {
+// NewClient creates a Client from config.
+func NewClient(config Config) *Client {
     return &Client{
-        APIKey:   apiKey,
-        Endpoint: "https://api.craite.ai/v1",
+        api:      NewApiClient(config),
+        registry: NewMCPToolRegistry(),
     }
 }
 
-// Generate creates Web3 code from a prompt
-func (c *Client) Generate(prompt string) (*GenerateResponse, error) {
-    // Implementation here
-    return nil, nil
+// Generate creates code from a prompt, trying each configured provider in
+// order. See ApiClient.Generate for the retry/fallback policy.
+func (c *Client) Generate(ctx context.Context, opts GenerateOptions) (*GenerateResult, error) {
+    return c.api.Generate(ctx, opts)
 }
 
-type GenerateResponse struct {
-    Code        string `json:"code"`
-    Explanation string `json:"explanation,omitempty"`
+// GenerateStream opens a streaming generation. See ApiClient.GenerateStream.
+func (c *Client) GenerateStream(ctx context.Context, opts GenerateOptions) (<-chan GenerateChunk, error) {
+    return c.api.GenerateStream(ctx, opts)
+}
+
+// GenerateWithTools runs the named MCP tools against the prompt, appends
+// their findings to it as extra context, and generates from the augmented
+// prompt. The returned result's ToolsUsed lists only the tools that
+// actually succeeded.
+func (c *Client) GenerateWithTools(ctx context.Context, opts GenerateOptions, tools []string) (*GenerateResult, error) {
+    var used []string
+    var toolContext strings.Builder
+
+    for _, name := range tools {
+        toolResult := c.registry.Execute(name, map[string]interface{}{
+            "code":     opts.Prompt,
+            "language": opts.Language,
+        })
+        if !toolResult.Success {
+            continue
+        }
+
+        used = append(used, name)
+        if data, err := json.Marshal(toolResult.Data); err == nil {
+            fmt.Fprintf(&toolContext, "\n\n[%s tool output]\n%s", name, data)
+        }
+    }
+
+    augmented := opts
+    augmented.Prompt = opts.Prompt + toolContext.String()
+
+    result, err := c.api.Generate(ctx, augmented)
+    if err != nil {
+        return nil, err
+    }
+
+    result.ToolsUsed = used
+    return result, nil
 }
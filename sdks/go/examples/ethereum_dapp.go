@@ -1,5 +1,6 @@
 package main
 import (
+"context"
 "fmt"
 "log"
 "os"
@@ -15,10 +16,20 @@ if apiKey == "" {
 }
 
 // Create client
-client := craite.NewClient(apiKey)
+client := craite.NewClient(craite.Config{
+    APIKey:   apiKey,
+    Provider: craite.OpenAI,
+    Model:    "gpt-4",
+})
 
 // Generate a decentralized exchange
-result, err := client.Generate("Create a Uniswap-style DEX with liquidity pools")
+result, err := client.Generate(context.Background(), craite.GenerateOptions{
+    Prompt:      "Create a Uniswap-style DEX with liquidity pools",
+    Language:    "solidity",
+    Mode:        craite.Production,
+    Temperature: 0.7,
+    MaxTokens:   2000,
+})
 if err != nil {
     log.Fatal(err)
 }
@@ -0,0 +1,47 @@
+package craite_test
+import (
+"os/exec"
+"strings"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestGasOptimizationFlagsCachedLengthAndPublicVisibilityViaAst(t *testing.T) {
+if _, err := exec.LookPath("solc"); err != nil {
+t.Skip("solc not found in PATH; astGasSuggestions only runs when solc is available, skipping")
+}
+registry := craite.NewMCPToolRegistry()
+code := `pragma solidity ^0.8.19;
+contract Loop {
+uint256[] public items;
+function sum() public returns (uint256 total) {
+for (uint256 i = 0; i < items.length; i++) {
+total += items[i];
+}
+}
+}`
+result := registry.Execute("gas_optimization", map[string]interface{}{
+"code": code,
+"chain": "ethereum",
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+suggestions, _ := data["suggestions"].([]map[string]interface{})
+loopFound := false
+visibilityFound := false
+for _, s := range suggestions {
+if s["type"] == "loops" && s["line"] != nil && s["line"] != 0 {
+loopFound = true
+}
+if s["type"] == "functions" && strings.Contains(s["suggestion"].(string), `Function "sum" is public`) {
+visibilityFound = true
+}
+}
+if !loopFound {
+t.Errorf("expected an AST-derived loop suggestion carrying a source line, not the substring-heuristic fallback; got %v", suggestions)
+}
+if !visibilityFound {
+t.Errorf("expected the AST-derived public-visibility suggestion naming the function, got %v", suggestions)
+}
+}
@@ -0,0 +1,56 @@
+package craite_test
+import (
+"strings"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestBindingGeneratorOmitsUnusedImports(t *testing.T) {
+registry := craite.NewMCPToolRegistry()
+abi := `[{"type":"event","name":"Ping","inputs":[{"name":"from","type":"bool","indexed":true}]}]`
+result := registry.Execute("binding_generator", map[string]interface{}{
+"abi": abi,
+"pkg": "bindings",
+"contract_name": "Pinger",
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+source := data["source"].(string)
+if strings.Contains(source, "\"github.com/ethereum/go-ethereum/common\"") {
+t.Errorf("expected no common import for an ABI with no address-typed args, got:\n%s", source)
+}
+if strings.Contains(source, "\"math/big\"") {
+t.Errorf("expected no math/big import for an ABI with no int/uint-typed args, got:\n%s", source)
+}
+if !strings.Contains(source, "sub      event.Subscription") {
+t.Errorf("expected the iterator to use event.Subscription, got:\n%s", source)
+}
+}
+func TestBindingGeneratorMapsArrayArgsToGoSlices(t *testing.T) {
+registry := craite.NewMCPToolRegistry()
+abi := `[{"type":"event","name":"TransferBatch","inputs":[
+{"name":"operator","type":"address","indexed":true},
+{"name":"ids","type":"uint256[]","indexed":false},
+{"name":"values","type":"uint256[]","indexed":false}
+]}]`
+result := registry.Execute("binding_generator", map[string]interface{}{
+"abi": abi,
+"pkg": "bindings",
+"contract_name": "MultiToken",
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+source := data["source"].(string)
+if !strings.Contains(source, "Ids []*big.Int") {
+t.Errorf("expected a uint256[] arg to map to []*big.Int, got:\n%s", source)
+}
+if strings.Contains(source, "Ids *big.Int\n") {
+t.Errorf("uint256[] must not map to the scalar *big.Int, got:\n%s", source)
+}
+if !strings.Contains(source, "\"math/big\"") {
+t.Errorf("expected math/big import for a []*big.Int field, got:\n%s", source)
+}
+}
@@ -0,0 +1,38 @@
+package craite_test
+import (
+"context"
+"net/http"
+"net/http/httptest"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestGenerateFallsBackAcrossProviders(t *testing.T) {
+bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+w.WriteHeader(http.StatusBadRequest)
+w.Write([]byte(`{"error":"invalid request"}`))
+}))
+defer bad.Close()
+good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+w.Write([]byte(`{"response":"pragma solidity ^0.8.0;\ncontract C {}"}`))
+}))
+defer good.Close()
+client := craite.NewClient(craite.Config{
+Providers: []craite.ProviderConfig{
+{Provider: craite.Local, Model: "llama3", Endpoint: bad.URL},
+{Provider: craite.Local, Model: "llama3", Endpoint: good.URL},
+},
+})
+result, err := client.Generate(context.Background(), craite.GenerateOptions{
+Prompt:   "make a contract",
+Language: "solidity",
+})
+if err != nil {
+t.Fatalf("expected the second provider to serve the request, got error: %v", err)
+}
+if result.Code == "" {
+t.Errorf("expected code from the fallback provider, got %+v", result)
+}
+if result.ProviderUsed != craite.Local {
+t.Errorf("expected ProviderUsed to record the provider that served the response, got %v", result.ProviderUsed)
+}
+}
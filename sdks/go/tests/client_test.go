@@ -1,11 +1,30 @@
 package craite_test
 import (
+"context"
+"net/http"
+"net/http/httptest"
 "testing"
 "github.com/CRAITE-CODE/craite/sdks/go"
 )
-func TestNewClient(t *testing.T) {
-client := craite.NewClient("test-key")
-if client.APIKey != "test-key" {
-t.Errorf("Expected APIKey to be 'test-key', got %s", client.APIKey)
+func TestNewClientGenerate(t *testing.T) {
+server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+w.Write([]byte(`{"response":"pragma solidity ^0.8.0;\ncontract C {}"}`))
+}))
+defer server.Close()
+client := craite.NewClient(craite.Config{
+APIKey:   "test-key",
+Provider: craite.Local,
+Model:    "llama3",
+Endpoint: server.URL,
+})
+result, err := client.Generate(context.Background(), craite.GenerateOptions{
+Prompt:   "make a contract",
+Language: "solidity",
+})
+if err != nil {
+t.Fatalf("expected success, got error: %v", err)
+}
+if result.Code == "" {
+t.Errorf("expected NewClient's Generate to return the decoded code, got %+v", result)
 }
 }
@@ -0,0 +1,24 @@
+package craite_test
+import (
+"strings"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestBridgeTemplateDeclaresItsValidatorCheck(t *testing.T) {
+registry := craite.NewMCPToolRegistry()
+result := registry.Execute("bridge_template", map[string]interface{}{
+"pattern": "lock_mint",
+"validator_set": "multisig",
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+dest := data["dest_template"].(string)
+if !strings.Contains(dest, "_hasMultisigApproval") {
+t.Errorf("expected the mint function to call _hasMultisigApproval, got:\n%s", dest)
+}
+if !strings.Contains(dest, "function _hasMultisigApproval(") {
+t.Errorf("expected _hasMultisigApproval to actually be declared, not just referenced, got:\n%s", dest)
+}
+}
@@ -0,0 +1,40 @@
+package craite_test
+import (
+"os/exec"
+"strings"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestSecurityAuditDoesNotSilenceCcipChecksViaComment(t *testing.T) {
+if _, err := exec.LookPath("solc"); err != nil {
+t.Skip("solc not found in PATH; astCcipIssues only runs when solc is available, skipping")
+}
+registry := craite.NewMCPToolRegistry()
+code := `pragma solidity ^0.8.19;
+// onlyRouter and sourceChainSelector are mentioned here in a comment only,
+// not actually enforced in _ccipReceive below.
+contract FakeReceiver {
+function _ccipReceive(bytes memory message) internal {
+for (uint256 i = 0; i < message.length; i++) {}
+}
+}`
+result := registry.Execute("security_audit", map[string]interface{}{
+"code": code,
+"language": "solidity",
+"tool": "none",
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+issues, _ := data["issues"].([]map[string]interface{})
+missingRouter := false
+for _, issue := range issues {
+if strings.Contains(issue["type"].(string), "ccip_missing_only_router") {
+missingRouter = true
+}
+}
+if !missingRouter {
+t.Errorf("expected ccip_missing_only_router even though the words appear in a comment, got %v", issues)
+}
+}
@@ -0,0 +1,21 @@
+package craite_test
+import (
+"strings"
+"testing"
+"github.com/CRAITE-CODE/craite/sdks/go"
+)
+func TestFeeAbstractionParameterizesAcceptedTokens(t *testing.T) {
+registry := craite.NewMCPToolRegistry()
+result := registry.Execute("fee_abstraction", map[string]interface{}{
+"pattern": "paymaster",
+"accepted_tokens": []string{"USDC", "DAI"},
+})
+if !result.Success {
+t.Fatalf("expected success, got error: %s", result.Error)
+}
+data := result.Data.(map[string]interface{})
+tmpl := data["template"].(string)
+if !strings.Contains(tmpl, "_usdcToken") || !strings.Contains(tmpl, "_daiToken") {
+t.Errorf("expected the paymaster constructor to take one parameter per accepted token, got:\n%s", tmpl)
+}
+}